@@ -0,0 +1,59 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+)
+
+// rawBytesCodec passes gRPC messages through as opaque bytes instead of
+// marshaling through protobuf. It lets InvokeDynamicGRPC replay a call using
+// only the request bytes captured by the proxy, without the service's
+// generated stubs or a descriptor registry to unmarshal into.
+type rawBytesCodec struct{}
+
+func (rawBytesCodec) Marshal(v interface{}) ([]byte, error) {
+	switch b := v.(type) {
+	case []byte:
+		return b, nil
+	case *[]byte:
+		return *b, nil
+	default:
+		return nil, fmt.Errorf("rawBytesCodec: unsupported type %T", v)
+	}
+}
+
+func (rawBytesCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("rawBytesCodec: unsupported type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (rawBytesCodec) Name() string { return "keploy-raw-bytes" }
+
+func init() {
+	encoding.RegisterCodec(rawBytesCodec{})
+}
+
+// InvokeDynamicGRPC replays a recorded unary gRPC call against conn using the
+// raw request bytes captured at record time, and returns the raw response
+// bytes. header/trailer are populated with the server's response metadata so
+// the caller can compare them against what was recorded.
+func InvokeDynamicGRPC(ctx context.Context, conn *grpc.ClientConn, fullMethod string, reqBytes []byte, header, trailer *metadata.MD) ([]byte, error) {
+	var respBytes []byte
+	err := conn.Invoke(ctx, fullMethod, reqBytes, &respBytes,
+		grpc.CallContentSubtype(rawBytesCodec{}.Name()),
+		grpc.Header(header),
+		grpc.Trailer(trailer),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return respBytes, nil
+}
@@ -0,0 +1,53 @@
+package pkg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.keploy.io/server/v2/pkg/models"
+	"go.uber.org/zap"
+)
+
+// SimulateHTTP replays a recorded HTTP request and returns the response the
+// target service actually sent back, so the caller can diff it against the
+// recording.
+func SimulateHTTP(ctx context.Context, tc models.TestCase, testSetID string, logger *zap.Logger, apiTimeout uint64) (*models.HTTPResp, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(apiTimeout)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, tc.HTTPReq.Method, tc.HTTPReq.URL, strings.NewReader(tc.HTTPReq.Body))
+	if err != nil {
+		logger.Error("failed to build the replay request", zap.Error(err), zap.String("test set id", testSetID))
+		return nil, err
+	}
+	for key, value := range tc.HTTPReq.Header {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		logger.Error("failed to replay the http request", zap.Error(err), zap.String("test case id", tc.Name))
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.Error("failed to read the replayed response body", zap.Error(err), zap.String("test case id", tc.Name))
+		return nil, err
+	}
+
+	header := make(map[string]string, len(resp.Header))
+	for key := range resp.Header {
+		header[key] = resp.Header.Get(key)
+	}
+
+	return &models.HTTPResp{
+		StatusCode: resp.StatusCode,
+		Header:     header,
+		Body:       string(body),
+	}, nil
+}
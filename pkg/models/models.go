@@ -0,0 +1,100 @@
+package models
+
+import "time"
+
+// Kind identifies which protocol a recorded TestCase/mock belongs to.
+type Kind string
+
+const (
+	HTTP      Kind = "HTTP"
+	GRPC      Kind = "GRPC"
+	WebSocket Kind = "WEB_SOCKET"
+)
+
+// TestCase is a single recorded interaction that `keploy test` replays.
+// Exactly one of HTTPReq/GRPCReq/WSReq is populated, selected by Kind.
+type TestCase struct {
+	Name    string
+	Kind    Kind
+	HTTPReq *HTTPReq
+	GRPCReq *GRPCReq
+	WSReq   *WSReq
+}
+
+// HTTPReq is the recorded HTTP request to replay.
+type HTTPReq struct {
+	Method string
+	URL    string
+	Header map[string]string
+	Body   string
+}
+
+// HTTPResp is the response captured while replaying an HTTPReq.
+type HTTPResp struct {
+	StatusCode int
+	Header     map[string]string
+	Body       string
+}
+
+// GRPCReq is the recorded unary gRPC call to replay: the target address, the
+// fully-qualified method name (e.g. "/pkg.Service/Method"), the serialized
+// request message, and the metadata sent with the original call.
+type GRPCReq struct {
+	Target     string
+	FullMethod string
+	Metadata   map[string]string
+	Body       []byte
+	// TLS records whether the recorded call was made over a TLS connection, so
+	// replay can dial with matching transport credentials.
+	TLS bool
+}
+
+// GRPCResp is the response captured while replaying a GRPCReq.
+type GRPCResp struct {
+	Body     []byte
+	Metadata map[string][]string
+	Trailers map[string][]string
+}
+
+// WSFrameType mirrors gorilla/websocket's message/control-frame constants so
+// frames recorded by the proxy can be replayed without importing that
+// package from models.
+type WSFrameType int
+
+const (
+	WSText   WSFrameType = 1
+	WSBinary WSFrameType = 2
+	WSClose  WSFrameType = 8
+	WSPing   WSFrameType = 9
+	WSPong   WSFrameType = 10
+)
+
+// WSFrame is a single recorded WebSocket frame, along with the deadline that
+// applied when it was captured.
+type WSFrame struct {
+	Type    WSFrameType
+	Payload []byte
+	Timeout time.Duration
+}
+
+// WSReq is the recorded WebSocket session to replay: the URL that was
+// upgraded, and the ordered frame sequence exchanged over it.
+type WSReq struct {
+	URL    string
+	Frames []WSFrame
+}
+
+// WSResp is the frame sequence captured while replaying a WSReq.
+type WSResp struct {
+	Frames []WSFrame
+}
+
+// Resp wraps whichever response type a replay produced so a single return
+// value can carry HTTP, gRPC, or WebSocket results through SimulateRequest
+// and into the comparator. Exactly one field is populated, matching the
+// TestCase's Kind.
+type Resp struct {
+	HTTPResp *HTTPResp
+	GRPCResp *GRPCResp
+	WSResp   *WSResp
+}
@@ -0,0 +1,50 @@
+package replay
+
+import (
+	"bytes"
+	"reflect"
+
+	"go.keploy.io/server/v2/pkg/models"
+)
+
+// CompareResp diffs a replayed response against the one recorded in the
+// TestCase, dispatching on whichever of HTTPResp/GRPCResp/WSResp is
+// populated. It's the widened counterpart of the old HTTP-only comparator:
+// gRPC and WebSocket replies returned by SimulateRequest are now actually
+// asserted against the recording instead of being silently accepted.
+func CompareResp(expected *models.Resp, actual *models.Resp) bool {
+	if expected == nil || actual == nil {
+		return expected == actual
+	}
+	switch {
+	case expected.HTTPResp != nil:
+		return compareHTTPResp(expected.HTTPResp, actual.HTTPResp)
+	case expected.GRPCResp != nil:
+		return compareGRPCResp(expected.GRPCResp, actual.GRPCResp)
+	case expected.WSResp != nil:
+		return compareWSResp(expected.WSResp, actual.WSResp)
+	default:
+		return true
+	}
+}
+
+func compareHTTPResp(expected, actual *models.HTTPResp) bool {
+	if actual == nil {
+		return false
+	}
+	return expected.StatusCode == actual.StatusCode && expected.Body == actual.Body
+}
+
+func compareGRPCResp(expected, actual *models.GRPCResp) bool {
+	if actual == nil {
+		return false
+	}
+	return bytes.Equal(expected.Body, actual.Body)
+}
+
+func compareWSResp(expected, actual *models.WSResp) bool {
+	if actual == nil {
+		return false
+	}
+	return reflect.DeepEqual(expected.Frames, actual.Frames)
+}
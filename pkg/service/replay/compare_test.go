@@ -0,0 +1,86 @@
+package replay
+
+import (
+	"testing"
+
+	"go.keploy.io/server/v2/pkg/models"
+)
+
+func TestCompareResp(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected *models.Resp
+		actual   *models.Resp
+		want     bool
+	}{
+		{
+			name:     "both nil",
+			expected: nil,
+			actual:   nil,
+			want:     true,
+		},
+		{
+			name:     "expected nil, actual not",
+			expected: nil,
+			actual:   &models.Resp{HTTPResp: &models.HTTPResp{StatusCode: 200}},
+			want:     false,
+		},
+		{
+			name:     "matching http responses",
+			expected: &models.Resp{HTTPResp: &models.HTTPResp{StatusCode: 200, Body: "ok"}},
+			actual:   &models.Resp{HTTPResp: &models.HTTPResp{StatusCode: 200, Body: "ok"}},
+			want:     true,
+		},
+		{
+			name:     "mismatched http status codes",
+			expected: &models.Resp{HTTPResp: &models.HTTPResp{StatusCode: 200, Body: "ok"}},
+			actual:   &models.Resp{HTTPResp: &models.HTTPResp{StatusCode: 500, Body: "ok"}},
+			want:     false,
+		},
+		{
+			name:     "matching grpc responses",
+			expected: &models.Resp{GRPCResp: &models.GRPCResp{Body: []byte("hello")}},
+			actual:   &models.Resp{GRPCResp: &models.GRPCResp{Body: []byte("hello")}},
+			want:     true,
+		},
+		{
+			name:     "mismatched grpc bodies",
+			expected: &models.Resp{GRPCResp: &models.GRPCResp{Body: []byte("hello")}},
+			actual:   &models.Resp{GRPCResp: &models.GRPCResp{Body: []byte("goodbye")}},
+			want:     false,
+		},
+		{
+			name: "matching websocket frame sequences",
+			expected: &models.Resp{WSResp: &models.WSResp{Frames: []models.WSFrame{
+				{Type: models.WSText, Payload: []byte("hi")},
+			}}},
+			actual: &models.Resp{WSResp: &models.WSResp{Frames: []models.WSFrame{
+				{Type: models.WSText, Payload: []byte("hi")},
+			}}},
+			want: true,
+		},
+		{
+			name: "mismatched websocket frame sequences",
+			expected: &models.Resp{WSResp: &models.WSResp{Frames: []models.WSFrame{
+				{Type: models.WSText, Payload: []byte("hi")},
+			}}},
+			actual: &models.Resp{WSResp: &models.WSResp{Frames: []models.WSFrame{
+				{Type: models.WSText, Payload: []byte("bye")},
+			}}},
+			want: false,
+		},
+		{
+			name:     "actual missing the expected protocol's response",
+			expected: &models.Resp{GRPCResp: &models.GRPCResp{Body: []byte("hello")}},
+			actual:   &models.Resp{},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareResp(tt.expected, tt.actual); got != tt.want {
+				t.Errorf("CompareResp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,14 +2,21 @@ package replay
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"go.keploy.io/server/v2/config"
 	"go.keploy.io/server/v2/pkg"
 	"go.keploy.io/server/v2/pkg/models"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 type TestReportVerdict struct {
@@ -61,7 +68,12 @@ func NewTestUtils(apiTimeout uint64, logger *zap.Logger) RequestEmulator {
 	}
 }
 
-func (t *testUtils) SimulateRequest(ctx context.Context, _ uint64, tc *models.TestCase, testSetID string) (*models.HTTPResp, error) {
+func (t *testUtils) SimulateRequest(ctx context.Context, _ uint64, tc *models.TestCase, testSetID string) (*models.Resp, error) {
+	// apiTimeout bounds the whole replay, not just the dial/handshake, so every
+	// branch below runs against the same deadline regardless of protocol.
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(t.apiTimeout)*time.Second)
+	defer cancel()
+
 	switch tc.Kind {
 	case models.HTTP:
 		t.logger.Debug("Before simulating the request", zap.Any("Test case", tc))
@@ -69,11 +81,149 @@ func (t *testUtils) SimulateRequest(ctx context.Context, _ uint64, tc *models.Te
 		resp, err := pkg.SimulateHTTP(ctx, *tc, testSetID, t.logger, t.apiTimeout)
 		t.logger.Debug("After simulating the request", zap.Any("test case id", tc.Name))
 		t.logger.Debug("After GetResp of the request", zap.Any("test case id", tc.Name))
-		return resp, err
+		if err != nil {
+			return nil, err
+		}
+		return &models.Resp{HTTPResp: resp}, nil
+	case models.GRPC:
+		t.logger.Debug("Before simulating the gRPC request", zap.Any("Test case", tc))
+		resp, err := t.simulateGRPC(ctx, tc, testSetID)
+		if err != nil {
+			return nil, err
+		}
+		return &models.Resp{GRPCResp: resp}, nil
+	case models.WebSocket:
+		t.logger.Debug("Before simulating the websocket request", zap.Any("Test case", tc))
+		resp, err := t.simulateWebSocket(ctx, tc, testSetID)
+		if err != nil {
+			return nil, err
+		}
+		return &models.Resp{WSResp: resp}, nil
 	}
 	return nil, nil
 }
 
+// simulateGRPC replays a recorded unary gRPC call through
+// pkg.InvokeDynamicGRPC, which sends the recorded request bytes as-is and
+// returns the raw response bytes, so the service's generated stubs aren't
+// needed: the target, method name, request bytes, and metadata all come from
+// the recorded TestCase. Messages are not decoded against a method
+// descriptor.
+func (t *testUtils) simulateGRPC(ctx context.Context, tc *models.TestCase, testSetID string) (*models.GRPCResp, error) {
+	creds := insecure.NewCredentials()
+	if tc.GRPCReq.TLS {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.NewClient(tc.GRPCReq.Target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.logger.Error("failed to dial the gRPC server for replay", zap.Error(err), zap.String("test set id", testSetID))
+		return nil, err
+	}
+	defer conn.Close()
+
+	md := metadata.New(tc.GRPCReq.Metadata)
+	outCtx := metadata.NewOutgoingContext(ctx, md)
+
+	var header, trailer metadata.MD
+	respBytes, err := pkg.InvokeDynamicGRPC(outCtx, conn, tc.GRPCReq.FullMethod, tc.GRPCReq.Body, &header, &trailer)
+	if err != nil {
+		t.logger.Error("failed to replay the gRPC request", zap.Error(err), zap.String("test case id", tc.Name))
+		return nil, err
+	}
+
+	return &models.GRPCResp{
+		Body:     respBytes,
+		Metadata: header,
+		Trailers: trailer,
+	}, nil
+}
+
+// wsHandlerWriteWait bounds how long a control-frame handler may block
+// echoing a pong/close ack back to the peer, independent of the recorded
+// frame's own deadline.
+const wsHandlerWriteWait = 5 * time.Second
+
+// simulateWebSocket replays the recorded frame sequence over a fresh
+// connection, honoring each frame's recorded deadline, and collects whatever
+// the server sends back so the comparator can diff it against the recording.
+func (t *testUtils) simulateWebSocket(ctx context.Context, tc *models.TestCase, testSetID string) (*models.WSResp, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, tc.WSReq.URL, nil)
+	if err != nil {
+		t.logger.Error("failed to dial the websocket server for replay", zap.Error(err), zap.String("test set id", testSetID))
+		return nil, err
+	}
+	defer conn.Close()
+
+	resp := &models.WSResp{}
+	// gorilla/websocket never surfaces control frames through ReadMessage —
+	// its default handlers swallow them. Install our own so a recorded
+	// ping/pong/close exchange is actually captured instead of silently
+	// dropped; they fire as a side effect of the ReadMessage calls below.
+	conn.SetPingHandler(func(payload string) error {
+		resp.Frames = append(resp.Frames, models.WSFrame{Type: models.WSPing, Payload: []byte(payload)})
+		return conn.WriteControl(websocket.PongMessage, []byte(payload), time.Now().Add(wsHandlerWriteWait))
+	})
+	conn.SetPongHandler(func(payload string) error {
+		resp.Frames = append(resp.Frames, models.WSFrame{Type: models.WSPong, Payload: []byte(payload)})
+		return nil
+	})
+	conn.SetCloseHandler(func(code int, payload string) error {
+		resp.Frames = append(resp.Frames, models.WSFrame{Type: models.WSClose, Payload: []byte(payload)})
+		return conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, ""), time.Now().Add(wsHandlerWriteWait))
+	})
+
+	for _, frame := range tc.WSReq.Frames {
+		deadline := time.Now().Add(frame.Timeout)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		if err := conn.SetWriteDeadline(deadline); err != nil {
+			return nil, err
+		}
+		switch frame.Type {
+		case models.WSClose:
+			if err := conn.WriteControl(websocket.CloseMessage, frame.Payload, deadline); err != nil {
+				return nil, err
+			}
+		case models.WSPing:
+			if err := conn.WriteControl(websocket.PingMessage, frame.Payload, deadline); err != nil {
+				return nil, err
+			}
+		case models.WSPong:
+			if err := conn.WriteControl(websocket.PongMessage, frame.Payload, deadline); err != nil {
+				return nil, err
+			}
+		case models.WSBinary:
+			if err := conn.WriteMessage(websocket.BinaryMessage, frame.Payload); err != nil {
+				return nil, err
+			}
+		default:
+			if err := conn.WriteMessage(websocket.TextMessage, frame.Payload); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+		msgType, payload, err := conn.ReadMessage()
+		if err != nil {
+			// Our close handler above already recorded the peer's close frame
+			// (if any) before the connection tore down, so this is expected
+			// once a close has been exchanged, not a replay failure.
+			if _, ok := err.(*websocket.CloseError); !ok {
+				t.logger.Debug("stopped reading websocket frames", zap.Error(err), zap.String("test case id", tc.Name))
+			}
+			break
+		}
+		resp.Frames = append(resp.Frames, models.WSFrame{
+			Type:    models.WSFrameType(msgType),
+			Payload: payload,
+		})
+	}
+	return resp, nil
+}
+
 type testStatusUtil struct {
 	logger   *zap.Logger
 	path     string
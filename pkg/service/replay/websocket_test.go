@@ -0,0 +1,98 @@
+package replay
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.keploy.io/server/v2/pkg/models"
+	"go.uber.org/zap"
+)
+
+// newEchoWSServer starts a server that, on receiving the client's "hello"
+// text frame, sends a ping control frame followed by a "world" text frame —
+// giving the replay side both a control frame and a data frame to read back
+// in the same ReadMessage call, then closes on the client's close frame.
+func newEchoWSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			msgType, payload, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if msgType == websocket.TextMessage && string(payload) == "hello" {
+				deadline := time.Now().Add(time.Second)
+				if err := conn.WriteControl(websocket.PingMessage, []byte("ping-payload"), deadline); err != nil {
+					return
+				}
+				if err := conn.WriteMessage(websocket.TextMessage, []byte("world")); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	return server
+}
+
+func TestSimulateWebSocket(t *testing.T) {
+	server := newEchoWSServer(t)
+	defer server.Close()
+
+	wsURL := "ws" + server.URL[len("http"):]
+
+	tc := &models.TestCase{
+		Name: "ws-frame-loop",
+		Kind: models.WebSocket,
+		WSReq: &models.WSReq{
+			URL: wsURL,
+			Frames: []models.WSFrame{
+				{Type: models.WSText, Payload: []byte("hello"), Timeout: time.Second},
+				{Type: models.WSClose, Payload: []byte("bye"), Timeout: time.Second},
+			},
+		},
+	}
+
+	tu := &testUtils{logger: zap.NewNop(), apiTimeout: 5}
+	resp, err := tu.simulateWebSocket(context.Background(), tc, "test-set-0")
+	if err != nil {
+		t.Fatalf("simulateWebSocket() unexpected error: %v", err)
+	}
+
+	if len(resp.Frames) < 2 {
+		t.Fatalf("simulateWebSocket() captured %d frames, want at least 2: %+v", len(resp.Frames), resp.Frames)
+	}
+
+	// The server's ping arrives (and is handled by SetPingHandler) before the
+	// "world" text reply, since ReadMessage processes control frames
+	// internally before returning the next data frame.
+	if got := resp.Frames[0]; got.Type != models.WSPing || string(got.Payload) != "ping-payload" {
+		t.Errorf("Frames[0] = %+v, want a WSPing frame with payload %q", got, "ping-payload")
+	}
+	if got := resp.Frames[1]; got.Type != models.WSText || string(got.Payload) != "world" {
+		t.Errorf("Frames[1] = %+v, want a WSText frame with payload %q", got, "world")
+	}
+
+	// The server answers our close frame with a close frame of its own (a
+	// protocol-error close here, since "bye" carries no valid close code);
+	// SetCloseHandler must capture that instead of silently dropping it.
+	foundClose := false
+	for _, f := range resp.Frames {
+		if f.Type == models.WSClose {
+			foundClose = true
+		}
+	}
+	if !foundClose {
+		t.Errorf("simulateWebSocket() frames = %+v, want a captured WSClose frame", resp.Frames)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"runtime"
@@ -276,6 +277,8 @@ func FindDockerCmd(cmd string) CmdType {
 	// Define patterns for Docker and Docker Compose
 	dockerPatterns := []string{"docker", "sudo docker"}
 	dockerComposePatterns := []string{"docker-compose", "sudo docker-compose", "docker compose", "sudo docker compose"}
+	podmanPatterns := []string{"podman", "sudo podman"}
+	podmanComposePatterns := []string{"podman-compose", "sudo podman-compose", "podman compose", "sudo podman compose"}
 
 	// Check for Docker Compose command patterns and file extensions
 	for _, pattern := range dockerComposePatterns {
@@ -283,12 +286,24 @@ func FindDockerCmd(cmd string) CmdType {
 			return DockerCompose
 		}
 	}
+	// Check for Podman Compose command patterns
+	for _, pattern := range podmanComposePatterns {
+		if strings.HasPrefix(cmdLower, pattern) {
+			return PodmanCompose
+		}
+	}
 	// Check for Docker command patterns
 	for _, pattern := range dockerPatterns {
 		if strings.HasPrefix(cmdLower, pattern) {
 			return Docker
 		}
 	}
+	// Check for Podman command patterns
+	for _, pattern := range podmanPatterns {
+		if strings.HasPrefix(cmdLower, pattern) {
+			return Podman
+		}
+	}
 	return Native
 }
 
@@ -297,9 +312,27 @@ type CmdType string
 const (
 	Docker        CmdType = "docker"
 	DockerCompose CmdType = "docker-compose"
+	Podman        CmdType = "podman"
+	PodmanCompose CmdType = "podman-compose"
 	Native        CmdType = "native"
 )
 
+// ContainerRuntime returns the container runtime that the alias/record/test
+// plumbing should use. An explicit --runtime override is returned as given,
+// trimmed and lowercased; otherwise it is "podman" or "docker", detected from
+// the user-supplied command.
+func ContainerRuntime(runtimeFlag string, command string) string {
+	if runtimeFlag != "" {
+		return strings.ToLower(strings.TrimSpace(runtimeFlag))
+	}
+	switch FindDockerCmd(command) {
+	case Podman, PodmanCompose:
+		return "podman"
+	default:
+		return "docker"
+	}
+}
+
 type RecordFlags struct {
 	Path             string
 	Command          string
@@ -311,6 +344,8 @@ type RecordFlags struct {
 	PassThroughPorts []uint
 	ConfigPath       string
 	EnableTele       bool
+	Runtime          string
+	WSLDistro        string
 }
 
 type TestFlags struct {
@@ -329,45 +364,305 @@ type TestFlags struct {
 	CoverageReportPath string
 	EnableTele         bool
 	WithCoverage       bool
+	Runtime            string
+	WSLDistro          string
+}
+
+// dockerContextInspect mirrors the subset of `docker context inspect` JSON we
+// care about: the context's name and its endpoint definitions.
+type dockerContextInspect struct {
+	Name      string `json:"Name"`
+	Endpoints struct {
+		Docker struct {
+			Host string `json:"Host"`
+		} `json:"docker"`
+	} `json:"Endpoints"`
 }
 
-func getAlias(ctx context.Context, logger *zap.Logger) (string, error) {
+// currentDockerContext runs `docker context inspect` for the active context
+// and returns its name and endpoint; Endpoints.Docker.Host holds the endpoint
+// URI (e.g. "unix:///var/run/docker.sock", "ssh://user@host",
+// "tcp://1.2.3.4:2376", "npipe:////./pipe/docker_engine").
+func currentDockerContext(ctx context.Context, logger *zap.Logger) (dockerContextInspect, error) {
+	cmd := exec.CommandContext(ctx, "docker", "context", "inspect", "--format", "{{json .}}")
+	out, err := cmd.Output()
+	if err != nil {
+		logger.Error("Failed to inspect the current docker context", zap.Error(err))
+		return dockerContextInspect{}, errors.New("failed to get alias")
+	}
+	// With --format, the template runs once per context, so the output is a
+	// single JSON object rather than an array.
+	var inspected dockerContextInspect
+	if err := json.Unmarshal(out, &inspected); err != nil {
+		logger.Error("Failed to parse docker context inspect output", zap.Error(err))
+		return dockerContextInspect{}, errors.New("failed to get alias")
+	}
+	return inspected, nil
+}
+
+// isUserSocketContext reports whether a unix socket endpoint belongs to a
+// user-managed Docker runtime living under ~/.colima or ~/.docker in the
+// user's home directory (colima, Docker Desktop's linux vm) rather than the
+// system default at /var/run/docker.sock. Sockets elsewhere, such as a
+// rootless dockerd's $XDG_RUNTIME_DIR/docker.sock, do not match.
+func isUserSocketContext(socketPath string) bool {
+	home := os.Getenv("HOME")
+	if home == "" {
+		return false
+	}
+	return strings.HasPrefix(socketPath, home+"/.colima") || strings.HasPrefix(socketPath, home+"/.docker")
+}
+
+// isColimaSocket reports whether a unix socket endpoint belongs to colima
+// specifically. Unlike isUserSocketContext, this deliberately does NOT match
+// Docker Desktop's own socket under ~/.docker/run/docker.sock — Desktop's
+// linux VM still needs the named debugfs volume, only colima's lightweight VM
+// needs the raw host-path bind mount.
+func isColimaSocket(socketPath string) bool {
+	home := os.Getenv("HOME")
+	if home == "" {
+		return false
+	}
+	return strings.HasPrefix(socketPath, home+"/.colima")
+}
+
+// resolveDockerSocket falls back through $DOCKER_HOST, the rootless runtime
+// socket, and finally the system default when the context itself didn't name
+// a socket explicitly.
+func resolveDockerSocket() string {
+	if dockerHost := os.Getenv("DOCKER_HOST"); strings.HasPrefix(dockerHost, "unix://") {
+		return strings.TrimPrefix(dockerHost, "unix://")
+	}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		if _, err := os.Stat(runtimeDir + "/docker.sock"); err == nil {
+			return runtimeDir + "/docker.sock"
+		}
+	}
+	return "/var/run/docker.sock"
+}
+
+func getAlias(ctx context.Context, logger *zap.Logger, wslDistro string) (string, error) {
 	// Get the name of the operating system.
 	osName := runtime.GOOS
 	//TODO: configure the hardcoded port mapping
+	dockerBin := "docker"
+	if osName == "linux" {
+		dockerBin = "sudo docker"
+	}
+	base := dockerBin + " run --pull always --name keploy-v2 -e BINARY_TO_DOCKER=true -p 16789:16789 --privileged --pid=host -it -v " + os.Getenv("PWD") + ":" + os.Getenv("PWD") + " -w " + os.Getenv("PWD") + " -v /sys/fs/cgroup:/sys/fs/cgroup -v /sys/fs/bpf:/sys/fs/bpf -v " + os.Getenv("HOME") + "/.keploy-config:/root/.keploy-config -v " + os.Getenv("HOME") + "/.keploy:/root/.keploy --rm ghcr.io/keploy/keploy "
 	switch osName {
-	case "linux":
-		alias := "sudo docker run --pull always --name keploy-v2 -e BINARY_TO_DOCKER=true -p 16789:16789 --privileged --pid=host -it -v " + os.Getenv("PWD") + ":" + os.Getenv("PWD") + " -w " + os.Getenv("PWD") + " -v /sys/fs/cgroup:/sys/fs/cgroup -v /sys/kernel/debug:/sys/kernel/debug -v /sys/fs/bpf:/sys/fs/bpf -v /var/run/docker.sock:/var/run/docker.sock -v " + os.Getenv("HOME") + "/.keploy-config:/root/.keploy-config -v " + os.Getenv("HOME") + "/.keploy:/root/.keploy --rm ghcr.io/keploy/keploy "
-		return alias, nil
-	case "darwin":
-		cmd := exec.CommandContext(ctx, "docker", "context", "ls", "--format", "{{.Name}}\t{{.Current}}")
-		out, err := cmd.Output()
+	case "linux", "darwin":
+		dockerCtx, err := currentDockerContext(ctx, logger)
 		if err != nil {
-			logger.Error("Failed to get the current docker context", zap.Error(err))
-			return "", errors.New("failed to get alias")
+			return "", err
+		}
+		endpoint := dockerCtx.Endpoints.Docker.Host
+		if endpoint == "" {
+			endpoint = "unix://" + resolveDockerSocket()
 		}
-		dockerContext := strings.Split(strings.TrimSpace(string(out)), "\n")[0]
-		if len(dockerContext) == 0 {
-			logger.Error("Could not get the current docker context")
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil {
+			logger.Error("Failed to parse the docker context endpoint", zap.Error(err))
 			return "", errors.New("failed to get alias")
 		}
-		dockerContext = strings.Split(dockerContext, "\n")[0]
-		if dockerContext == "colima" {
-			logger.Info("Starting keploy in docker with colima context, as that is the current context.")
-			alias := "docker run --pull always --name keploy-v2 -e BINARY_TO_DOCKER=true -p 16789:16789 --privileged --pid=host -it -v " + os.Getenv("PWD") + ":" + os.Getenv("PWD") + " -w " + os.Getenv("PWD") + " -v /sys/fs/cgroup:/sys/fs/cgroup -v /sys/kernel/debug:/sys/kernel/debug -v /sys/fs/bpf:/sys/fs/bpf -v /var/run/docker.sock:/var/run/docker.sock -v " + os.Getenv("HOME") + "/.keploy-config:/root/.keploy-config -v " + os.Getenv("HOME") + "/.keploy:/root/.keploy --rm ghcr.io/keploy/keploy "
+
+		debugMount := " -v /sys/kernel/debug:/sys/kernel/debug"
+		if osName == "darwin" && !isColimaSocket(endpointURL.Path) && dockerCtx.Name != "colima" {
+			// Docker Desktop's linux VM (the default darwin context, and any
+			// other non-colima context) exposes debugfs under a named volume,
+			// not a host path — only colima needs the raw host-path bind mount.
+			debugMount = " -v debugfs:/sys/kernel/debug:rw"
+		}
+
+		switch endpointURL.Scheme {
+		case "ssh", "tcp":
+			logger.Info("Starting keploy in docker against a remote context, forwarding DOCKER_HOST instead of a socket bind mount.", zap.String("context", dockerCtx.Name))
+			alias := base + debugMount + " -e DOCKER_HOST=" + endpoint
+			if endpointURL.Scheme == "ssh" {
+				alias += " -v $SSH_AUTH_SOCK:/ssh-agent -e SSH_AUTH_SOCK=/ssh-agent"
+			}
 			return alias, nil
-		} else {
-			logger.Info("Starting keploy in docker with default context, as that is the current context.")
-			alias := "docker run --pull always --name keploy-v2 -e BINARY_TO_DOCKER=true -p 16789:16789 --privileged --pid=host -it -v " + os.Getenv("PWD") + ":" + os.Getenv("PWD") + " -w " + os.Getenv("PWD") + " -v /sys/fs/cgroup:/sys/fs/cgroup -v debugfs:/sys/kernel/debug:rw -v /sys/fs/bpf:/sys/fs/bpf -v /var/run/docker.sock:/var/run/docker.sock -v " + os.Getenv("HOME") + "/.keploy-config:/root/.keploy-config -v " + os.Getenv("HOME") + "/.keploy:/root/.keploy --rm ghcr.io/keploy/keploy "
+		case "unix", "":
+			sock := endpointURL.Path
+			if sock == "" {
+				sock = resolveDockerSocket()
+			}
+			if dockerCtx.Name == "colima" || isUserSocketContext(sock) {
+				logger.Info("Starting keploy in docker with a user-managed context, as that is the current context.", zap.String("context", dockerCtx.Name))
+			} else {
+				logger.Info("Starting keploy in docker with default context, as that is the current context.")
+			}
+			alias := base + debugMount + " -v " + sock + ":" + sock + " -e DOCKER_HOST=unix://" + sock
 			return alias, nil
+		case "npipe":
+			// A `npipe://` endpoint means the docker CLI we just shelled out to
+			// is actually talking to a Windows Docker Desktop, not a real
+			// linux/darwin host — getWSLAlias (the "windows" case below) is the
+			// supported path for that, since named pipes can't be bind-mounted
+			// into a linux container the way a unix socket can.
+			logger.Error("Docker Desktop on Windows isn't supported through this path; run keploy from PowerShell/cmd instead, which routes through WSL2.")
+			return "", errors.New("failed to get alias")
+		default:
+			logger.Error("Unsupported docker context endpoint scheme", zap.String("scheme", endpointURL.Scheme))
+			return "", errors.New("failed to get alias")
 		}
-	case "Windows":
-		logger.Error("Windows is not supported. Use WSL2 instead.")
-		return "", errors.New("failed to get alias")
+	case "windows":
+		return getWSLAlias(ctx, logger, wslDistro)
 	}
 	return "", errors.New("failed to get alias")
 }
 
+// parseWSLDistroList parses the table printed by `wsl.exe -l -v` (NAME, STATE,
+// VERSION columns, default distro marked with a leading "*") and picks the
+// default distro if it's running, falling back to the first other running
+// distro otherwise. A stopped distro is never picked, since the eBPF/debugfs
+// checks right after this need it to actually be up.
+func parseWSLDistroList(output string) (string, error) {
+	// wsl.exe's output is UTF-16LE; reading it as plain text leaves a NUL
+	// high byte after every ASCII character, so strip them before splitting.
+	cleaned := strings.ReplaceAll(output, "\x00", "")
+	lines := strings.FieldsFunc(cleaned, func(r rune) bool { return r == '\n' || r == '\r' })
+
+	fallback := ""
+	for i, line := range lines {
+		if i == 0 {
+			continue // header row: "NAME STATE VERSION"
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		isDefault := strings.HasPrefix(trimmed, "*")
+		fields := strings.Fields(strings.TrimPrefix(trimmed, "*"))
+		if len(fields) < 2 {
+			continue
+		}
+		name, state := fields[0], fields[1]
+		if !strings.EqualFold(state, "Running") {
+			continue
+		}
+		if isDefault {
+			return name, nil
+		}
+		if fallback == "" {
+			fallback = name
+		}
+	}
+	if fallback != "" {
+		return fallback, nil
+	}
+	return "", errors.New("no running WSL2 distro found")
+}
+
+// defaultWSLDistro asks `wsl.exe -l -v` for the running WSL distros and picks
+// the default one (per parseWSLDistroList).
+func defaultWSLDistro(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "wsl.exe", "-l", "-v")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return parseWSLDistroList(string(out))
+}
+
+// wslPath translates a Windows path into its /mnt/c/... equivalent inside the
+// given distro via `wsl.exe -d <distro> -- wslpath`, the same tool `wsl.exe`
+// itself uses internally for path translation.
+func wslPath(ctx context.Context, distro string, windowsPath string) (string, error) {
+	cmd := exec.CommandContext(ctx, "wsl.exe", "-d", distro, "--", "wslpath", "-a", windowsPath)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to translate %q into the %s distro: %w", windowsPath, distro, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// verifyWSLKernel confirms the distro is actually running a WSL2 (not WSL1)
+// kernel with the debugfs mount the eBPF proxy depends on.
+func verifyWSLKernel(ctx context.Context, logger *zap.Logger, distro string) error {
+	cmd := exec.CommandContext(ctx, "wsl.exe", "-d", distro, "--", "uname", "-r")
+	out, err := cmd.Output()
+	if err != nil {
+		logger.Error("Failed to read the kernel version inside the WSL distro", zap.Error(err), zap.String("distro", distro))
+		return errors.New("failed to get alias")
+	}
+	if !strings.Contains(strings.ToLower(string(out)), "microsoft-standard-wsl2") {
+		logger.Error("The selected distro is not running a WSL2 kernel; switch it with `wsl --set-version <distro> 2`.", zap.String("distro", distro), zap.String("kernel", strings.TrimSpace(string(out))))
+		return errors.New("failed to get alias")
+	}
+	if err := exec.CommandContext(ctx, "wsl.exe", "-d", distro, "--", "test", "-d", "/sys/kernel/debug").Run(); err != nil {
+		logger.Error("debugfs is not mounted inside the WSL distro; the eBPF proxy needs /sys/kernel/debug.", zap.String("distro", distro))
+		return errors.New("failed to get alias")
+	}
+	return nil
+}
+
+// getWSLAlias builds the alias keploy shells out to on Windows: it routes the
+// `docker run` invocation through a running WSL2 distro so PowerShell/cmd
+// users don't need a Linux shell of their own. distroOverride forces a
+// specific distro (the --wsl-distro flag); otherwise the default distro
+// reported by `wsl.exe -l -v` is used if it's running, else the first other
+// running distro (see parseWSLDistroList).
+func getWSLAlias(ctx context.Context, logger *zap.Logger, distroOverride string) (string, error) {
+	distro := distroOverride
+	if distro == "" {
+		found, err := defaultWSLDistro(ctx)
+		if err != nil {
+			logger.Error("Could not find a running WSL2 distro. Start one, or pass --wsl-distro explicitly.", zap.Error(err))
+			return "", errors.New("failed to get alias")
+		}
+		distro = found
+	}
+
+	if err := verifyWSLKernel(ctx, logger, distro); err != nil {
+		return "", err
+	}
+
+	// %CD% is a cmd.exe pseudo-variable, not a real environment variable, so it
+	// never shows up in the process environment block a child process inherits.
+	wd, err := os.Getwd()
+	if err != nil {
+		logger.Error("Failed to get the current working directory", zap.Error(err))
+		return "", errors.New("failed to get alias")
+	}
+	cwd, err := wslPath(ctx, distro, wd)
+	if err != nil {
+		return "", err
+	}
+	home, err := wslPath(ctx, distro, os.Getenv("USERPROFILE"))
+	if err != nil {
+		return "", err
+	}
+
+	alias := "wsl.exe -d " + distro + " -- sudo docker run --pull always --name keploy-v2 -e BINARY_TO_DOCKER=true -p 16789:16789 --privileged --pid=host -it -v " + cwd + ":" + cwd + " -w " + cwd + " -v /sys/fs/cgroup:/sys/fs/cgroup -v /sys/kernel/debug:/sys/kernel/debug -v /sys/fs/bpf:/sys/fs/bpf -v /var/run/docker.sock:/var/run/docker.sock -v " + home + "/.keploy-config:/root/.keploy-config -v " + home + "/.keploy:/root/.keploy --rm ghcr.io/keploy/keploy "
+	return alias, nil
+}
+
+// podmanSocket resolves the rootless Podman socket, preferring $XDG_RUNTIME_DIR
+// (where `podman system service` listens by default) and falling back to the
+// root socket for setups that run the Podman daemon as root.
+func podmanSocket() string {
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return runtimeDir + "/podman/podman.sock"
+	}
+	return "/run/podman/podman.sock"
+}
+
+// getPodmanAlias builds the `podman run` invocation for launching the keploy
+// container. Unlike getAlias, it mounts the rootless Podman socket, keeps the
+// caller's UID mapped via --userns=keep-id, and drops --privileged/--pid=host
+// in favor of the minimum capabilities the eBPF proxy needs.
+func getPodmanAlias(_ context.Context, logger *zap.Logger) (string, error) {
+	osName := runtime.GOOS
+	if osName != "linux" {
+		logger.Error("Podman runtime is only supported on Linux")
+		return "", errors.New("failed to get alias")
+	}
+	sock := podmanSocket()
+	alias := "podman run --pull always --name keploy-v2 -e BINARY_TO_DOCKER=true -p 16789:16789 --userns=keep-id --cap-add=SYS_PTRACE --cap-add=NET_ADMIN --cap-add=NET_RAW -it -v " + os.Getenv("PWD") + ":" + os.Getenv("PWD") + " -w " + os.Getenv("PWD") + " -v /sys/fs/cgroup:/sys/fs/cgroup -v /sys/kernel/debug:/sys/kernel/debug -v /sys/fs/bpf:/sys/fs/bpf -v " + sock + ":" + sock + " -v " + os.Getenv("HOME") + "/.keploy-config:/root/.keploy-config -v " + os.Getenv("HOME") + "/.keploy:/root/.keploy --rm ghcr.io/keploy/keploy "
+	return alias, nil
+}
+
 //func appendFlags(flagName string, flagValue string) string {
 //	if len(flagValue) > 0 {
 //		// Check for = in the flagName.
@@ -379,9 +674,9 @@ func getAlias(ctx context.Context, logger *zap.Logger) (string, error) {
 //	return ""
 //}
 
-func RunInDocker(ctx context.Context, logger *zap.Logger, command string) error {
+func RunInDocker(ctx context.Context, logger *zap.Logger, command string, wslDistro string) error {
 	//Get the correct keploy alias.
-	keployAlias, err := getAlias(ctx, logger)
+	keployAlias, err := getAlias(ctx, logger, wslDistro)
 	if err != nil {
 		return err
 	}
@@ -398,6 +693,54 @@ func RunInDocker(ctx context.Context, logger *zap.Logger, command string) error
 	return nil
 }
 
+// RunInPodman launches keploy inside a Podman container, mirroring RunInDocker
+// but using the rootless Podman socket and capability set instead of Docker's.
+func RunInPodman(ctx context.Context, logger *zap.Logger, command string) error {
+	keployAlias, err := getPodmanAlias(ctx, logger)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", keployAlias+" "+command)
+	cmd.Stdout = os.Stdout
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	logger.Debug("This is the keploy alias", zap.String("keployAlias:", keployAlias))
+	err = cmd.Run()
+	if err != nil {
+		logger.Error("Failed to start keploy in podman", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RunInContainerRuntime auto-picks Docker or Podman based on the user's
+// recorded/replay command, unless runtimeOverride (the --runtime flag on
+// RecordFlags/TestFlags) forces one. wslDistro is only consulted on Windows,
+// where RunInDocker shells out through a WSL2 distro (see getWSLAlias); it's
+// ignored for the Podman path, which isn't supported on Windows.
+func RunInContainerRuntime(ctx context.Context, logger *zap.Logger, command string, runtimeOverride string, wslDistro string) error {
+	if ContainerRuntime(runtimeOverride, command) == "podman" {
+		return RunInPodman(ctx, logger, command)
+	}
+	return RunInDocker(ctx, logger, command, wslDistro)
+}
+
+// RunRecordInContainer launches `keploy record` in a container, honoring the
+// --runtime (Docker/Podman) and --wsl-distro overrides on RecordFlags. This
+// is the entry point the record cmd should call instead of RunInDocker
+// directly, so those flags actually take effect.
+func RunRecordInContainer(ctx context.Context, logger *zap.Logger, flags RecordFlags) error {
+	return RunInContainerRuntime(ctx, logger, flags.Command, flags.Runtime, flags.WSLDistro)
+}
+
+// RunTestInContainer launches `keploy test` in a container, honoring the
+// --runtime (Docker/Podman) and --wsl-distro overrides on TestFlags. This is
+// the entry point the test cmd should call instead of RunInDocker directly,
+// so those flags actually take effect.
+func RunTestInContainer(ctx context.Context, logger *zap.Logger, flags TestFlags) error {
+	return RunInContainerRuntime(ctx, logger, flags.Command, flags.Runtime, flags.WSLDistro)
+}
+
 // Keys returns an array containing the keys of the given map.
 func Keys(m map[string][]string) []string {
 	keys := make([]string, 0, len(m))
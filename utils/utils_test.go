@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestFindDockerCmd(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  string
+		want CmdType
+	}{
+		{"docker run", "docker run -d nginx", Docker},
+		{"sudo docker run", "sudo docker run -d nginx", Docker},
+		{"docker-compose up", "docker-compose up", DockerCompose},
+		{"sudo docker-compose up", "sudo docker-compose up", DockerCompose},
+		{"docker compose up", "docker compose up", DockerCompose},
+		{"podman run", "podman run -d nginx", Podman},
+		{"sudo podman run", "sudo podman run -d nginx", Podman},
+		{"podman-compose up", "podman-compose up", PodmanCompose},
+		{"sudo podman-compose up", "sudo podman-compose up", PodmanCompose},
+		{"podman compose up", "podman compose up", PodmanCompose},
+		{"native binary", "./my-app", Native},
+		{"case insensitive podman", "PODMAN run -d nginx", Podman},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindDockerCmd(tt.cmd); got != tt.want {
+				t.Errorf("FindDockerCmd(%q) = %v, want %v", tt.cmd, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerRuntime(t *testing.T) {
+	tests := []struct {
+		name        string
+		runtimeFlag string
+		command     string
+		want        string
+	}{
+		{"override forces podman", "podman", "docker run -d nginx", "podman"},
+		{"override forces docker", "docker", "podman run -d nginx", "docker"},
+		{"override is case-insensitive", "PODMAN", "docker run -d nginx", "podman"},
+		{"auto-detects podman from command", "", "podman run -d nginx", "podman"},
+		{"auto-detects podman compose from command", "", "podman-compose up", "podman"},
+		{"defaults to docker", "", "docker run -d nginx", "docker"},
+		{"defaults to docker for native commands", "", "./my-app", "docker"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContainerRuntime(tt.runtimeFlag, tt.command); got != tt.want {
+				t.Errorf("ContainerRuntime(%q, %q) = %q, want %q", tt.runtimeFlag, tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodmanSocket(t *testing.T) {
+	t.Run("prefers XDG_RUNTIME_DIR", func(t *testing.T) {
+		t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+		if got, want := podmanSocket(), "/run/user/1000/podman/podman.sock"; got != want {
+			t.Errorf("podmanSocket() = %q, want %q", got, want)
+		}
+	})
+	t.Run("falls back to the root socket", func(t *testing.T) {
+		t.Setenv("XDG_RUNTIME_DIR", "")
+		if got, want := podmanSocket(), "/run/podman/podman.sock"; got != want {
+			t.Errorf("podmanSocket() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestIsColimaSocket(t *testing.T) {
+	t.Setenv("HOME", "/home/user")
+	tests := []struct {
+		name       string
+		socketPath string
+		want       bool
+	}{
+		{"colima socket", "/home/user/.colima/default/docker.sock", true},
+		{"docker desktop socket", "/home/user/.docker/run/docker.sock", false},
+		{"system default socket", "/var/run/docker.sock", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isColimaSocket(tt.socketPath); got != tt.want {
+				t.Errorf("isColimaSocket(%q) = %v, want %v", tt.socketPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsUserSocketContext(t *testing.T) {
+	t.Setenv("HOME", "/home/user")
+	tests := []struct {
+		name       string
+		socketPath string
+		want       bool
+	}{
+		{"colima socket", "/home/user/.colima/default/docker.sock", true},
+		{"docker desktop socket", "/home/user/.docker/run/docker.sock", true},
+		{"system default socket", "/var/run/docker.sock", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUserSocketContext(tt.socketPath); got != tt.want {
+				t.Errorf("isUserSocketContext(%q) = %v, want %v", tt.socketPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDockerSocket(t *testing.T) {
+	t.Run("prefers a unix DOCKER_HOST", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "unix:///custom/docker.sock")
+		if got, want := resolveDockerSocket(), "/custom/docker.sock"; got != want {
+			t.Errorf("resolveDockerSocket() = %q, want %q", got, want)
+		}
+	})
+	t.Run("falls back to the system default when nothing else matches", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "")
+		t.Setenv("XDG_RUNTIME_DIR", "/does/not/exist")
+		if got, want := resolveDockerSocket(), "/var/run/docker.sock"; got != want {
+			t.Errorf("resolveDockerSocket() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestParseWSLDistroList(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "picks the default running distro",
+			output: "  NAME             STATE           VERSION\n* Ubuntu-20.04     Running         2\n  Debian           Stopped         2\n",
+			want:   "Ubuntu-20.04",
+		},
+		{
+			name:   "falls back to another running distro when the default is stopped",
+			output: "  NAME             STATE           VERSION\n* Ubuntu-20.04     Stopped         2\n  Debian           Running         2\n",
+			want:   "Debian",
+		},
+		{
+			name:    "errors when nothing is running",
+			output:  "  NAME             STATE           VERSION\n* Ubuntu-20.04     Stopped         2\n  Debian           Stopped         2\n",
+			wantErr: true,
+		},
+		{
+			name:    "errors on an empty list",
+			output:  "  NAME             STATE           VERSION\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWSLDistroList(tt.output)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseWSLDistroList() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseWSLDistroList() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseWSLDistroList() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}